@@ -1,20 +1,99 @@
-package main
+// Package aslang provides cgo bindings to the AS Lang Rust interpreter.
+package aslang
 
 /*
 #cgo LDFLAGS: -L../../target/release -laslang
 #include <stdlib.h>
 
+typedef struct {
+	char* stdout_data;
+	char* stderr_data;
+	int   exit_code;
+	char* diagnostics_json;
+	char* value_json;
+} AsResult;
+
 extern char* as_execute(const char* code);
 extern void as_free_string(char* s);
+
+extern AsResult* as_execute_v2(const char* code);
+extern void as_free_result(AsResult* result);
 */
 import "C"
 import (
+	"encoding/json"
 	"fmt"
 	"unsafe"
 )
 
-// Execute runs AS Lang code and returns the output string
-func Execute(code string) string {
+// Severity is the severity level of a Diagnostic.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+)
+
+// Diagnostic describes a single parse or runtime finding reported by the interpreter.
+type Diagnostic struct {
+	Line       int      `json:"line"`
+	Col        int      `json:"col"`
+	Severity   Severity `json:"severity"`
+	Message    string   `json:"message"`
+	Suggestion string   `json:"suggestion,omitempty"`
+}
+
+// Result is the structured outcome of running AS Lang code.
+type Result struct {
+	Stdout      string
+	Stderr      string
+	ExitCode    int
+	Diagnostics []Diagnostic
+	// Value is the JSON-encoded value the code evaluated to (e.g. a script's
+	// final expression or, for action.Runtime, the return value of
+	// main(args)). It is nil if the interpreter reported no value.
+	Value json.RawMessage
+}
+
+// ExecError is returned when AS Lang code fails to parse or run.
+type ExecError struct {
+	Result Result
+}
+
+func (e *ExecError) Error() string {
+	for _, d := range e.Result.Diagnostics {
+		if d.Severity == SeverityError {
+			return fmt.Sprintf("%d:%d: %s", d.Line, d.Col, d.Message)
+		}
+	}
+	return fmt.Sprintf("as execute failed with exit code %d", e.Result.ExitCode)
+}
+
+// Execute runs AS Lang code and returns a structured Result. error is non-nil
+// whenever the code fails to parse or raises a runtime error; callers can
+// still inspect the returned Result (stdout captured so far, diagnostics) even
+// when error is non-nil.
+func Execute(code string) (Result, error) {
+	cCode := C.CString(code)
+	defer C.free(unsafe.Pointer(cCode))
+
+	cResult := C.as_execute_v2(cCode)
+	defer C.as_free_result(cResult)
+
+	return decodeResultFields(
+		C.GoString(cResult.stdout_data),
+		C.GoString(cResult.stderr_data),
+		C.GoString(cResult.diagnostics_json),
+		C.GoString(cResult.value_json),
+		int(cResult.exit_code),
+	)
+}
+
+// executeLegacy is a thin shim over the original as_execute symbol, kept for
+// callers that only need the flattened stdout/error string and haven't moved
+// to Execute yet.
+func executeLegacy(code string) string {
 	cCode := C.CString(code)
 	defer C.free(unsafe.Pointer(cCode))
 
@@ -23,10 +102,3 @@ func Execute(code string) string {
 
 	return C.GoString(cResult)
 }
-
-func main() {
-	code := `print("Hello from Go!"); let x = 40 + 2; print(x);`
-	fmt.Printf("Running AS Lang from Go...\n")
-	output := Execute(code)
-	fmt.Printf("Output:\n%s\n", output)
-}
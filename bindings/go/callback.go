@@ -0,0 +1,210 @@
+package aslang
+
+/*
+#include <stdlib.h>
+#include <stdint.h>
+
+typedef enum {
+	AS_VALUE_INT,
+	AS_VALUE_FLOAT,
+	AS_VALUE_BOOL,
+	AS_VALUE_STRING,
+	AS_VALUE_LIST,
+	AS_VALUE_ERROR,
+} AsValueTag;
+
+typedef struct AsValue AsValue;
+
+extern AsValue* as_value_new_int(int64_t v);
+extern AsValue* as_value_new_float(double v);
+extern AsValue* as_value_new_bool(int v);
+extern AsValue* as_value_new_string(const char* v);
+extern AsValue* as_value_new_list(AsValue** items, int count);
+extern AsValue* as_value_new_error(const char* message);
+extern void as_value_free(AsValue* v);
+
+extern AsValueTag as_value_tag(AsValue* v);
+extern int64_t as_value_get_int(AsValue* v);
+extern double as_value_get_float(AsValue* v);
+extern int as_value_get_bool(AsValue* v);
+extern char* as_value_get_string(AsValue* v);
+extern int as_value_list_len(AsValue* v);
+extern AsValue* as_value_list_get(AsValue* v, int index);
+
+extern void as_session_register(void* session, const char* name, int arity, uintptr_t handle);
+
+extern AsValue* asGoCallbackTrampoline(uintptr_t handle, AsValue** args, int argc);
+*/
+import "C"
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// ValueKind identifies the concrete type carried by a Value.
+type ValueKind int
+
+const (
+	KindInt ValueKind = iota
+	KindFloat
+	KindBool
+	KindString
+	KindList
+	// KindError is never returned by a well-behaved Builtin; it is how a
+	// failing call is represented once it crosses back into AS Lang.
+	KindError
+)
+
+// Value is a tagged union mirroring the AS Lang runtime's value
+// representation, used to pass arguments and return values across the FFI
+// boundary for registered builtins.
+type Value struct {
+	Kind  ValueKind
+	Int   int64
+	Float float64
+	Bool  bool
+	Str   string
+	List  []Value
+}
+
+// Builtin is a Go function exposed to AS Lang scripts via Session.Register.
+type Builtin func(args []Value) (Value, error)
+
+var (
+	handlesMu  sync.Mutex
+	handles    = map[uintptr]Builtin{}
+	nextHandle uintptr
+)
+
+// Register exposes fn to AS Lang scripts running in this session under name.
+// arity is the number of arguments the script must pass; -1 means variadic.
+// Calls into name from AS Lang code trampoline through cgo into fn.
+func (s *Session) Register(name string, arity int, fn Builtin) error {
+	if s.ptr == nil {
+		return fmt.Errorf("aslang: session is closed")
+	}
+
+	handle := registerBuiltin(fn)
+
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	C.as_session_register(s.ptr, cName, C.int(arity), C.uintptr_t(handle))
+	runtime.KeepAlive(s)
+	return nil
+}
+
+// registerBuiltin stores fn in the handle table and returns the uintptr
+// handle to pass across the FFI boundary, since cgo rules forbid passing Go
+// pointers (including func values) to C directly.
+func registerBuiltin(fn Builtin) uintptr {
+	handlesMu.Lock()
+	defer handlesMu.Unlock()
+	nextHandle++
+	handles[nextHandle] = fn
+	return nextHandle
+}
+
+func lookupBuiltin(handle uintptr) (Builtin, bool) {
+	handlesMu.Lock()
+	defer handlesMu.Unlock()
+	fn, ok := handles[handle]
+	return fn, ok
+}
+
+// asGoCallbackTrampoline is the entry point the Rust interpreter calls back
+// into when AS Lang code invokes a registered builtin. Its exported C symbol
+// name must match the extern declaration above exactly.
+//
+//export asGoCallbackTrampoline
+func asGoCallbackTrampoline(handle C.uintptr_t, cArgs **C.AsValue, argc C.int) *C.AsValue {
+	fn, ok := lookupBuiltin(uintptr(handle))
+	if !ok {
+		return cErrorValue(fmt.Sprintf("aslang: unknown callback handle %d", handle))
+	}
+
+	n := int(argc)
+	rawArgs := unsafe.Slice(cArgs, n)
+	args := make([]Value, n)
+	for i, cv := range rawArgs {
+		args[i] = goValueFromC(cv)
+	}
+
+	result, err := fn(args)
+	if err != nil {
+		return cErrorValue(err.Error())
+	}
+	return cValueFromGo(result)
+}
+
+// cErrorValue builds an AS_VALUE_ERROR so the interpreter can distinguish a
+// builtin that failed from one that merely returned a string.
+func cErrorValue(message string) *C.AsValue {
+	cs := C.CString(message)
+	defer C.free(unsafe.Pointer(cs))
+	return C.as_value_new_error(cs)
+}
+
+func goValueFromC(v *C.AsValue) Value {
+	switch C.as_value_tag(v) {
+	case C.AS_VALUE_INT:
+		return Value{Kind: KindInt, Int: int64(C.as_value_get_int(v))}
+	case C.AS_VALUE_FLOAT:
+		return Value{Kind: KindFloat, Float: float64(C.as_value_get_float(v))}
+	case C.AS_VALUE_BOOL:
+		return Value{Kind: KindBool, Bool: C.as_value_get_bool(v) != 0}
+	case C.AS_VALUE_STRING:
+		return Value{Kind: KindString, Str: C.GoString(C.as_value_get_string(v))}
+	case C.AS_VALUE_LIST:
+		n := int(C.as_value_list_len(v))
+		list := make([]Value, n)
+		for i := 0; i < n; i++ {
+			list[i] = goValueFromC(C.as_value_list_get(v, C.int(i)))
+		}
+		return Value{Kind: KindList, List: list}
+	case C.AS_VALUE_ERROR:
+		return Value{Kind: KindError, Str: C.GoString(C.as_value_get_string(v))}
+	default:
+		return Value{}
+	}
+}
+
+func cValueFromGo(v Value) *C.AsValue {
+	switch v.Kind {
+	case KindInt:
+		return C.as_value_new_int(C.int64_t(v.Int))
+	case KindFloat:
+		return C.as_value_new_float(C.double(v.Float))
+	case KindBool:
+		b := 0
+		if v.Bool {
+			b = 1
+		}
+		return C.as_value_new_bool(C.int(b))
+	case KindString:
+		cs := C.CString(v.Str)
+		defer C.free(unsafe.Pointer(cs))
+		return C.as_value_new_string(cs)
+	case KindList:
+		items := make([]*C.AsValue, len(v.List))
+		for i, item := range v.List {
+			items[i] = cValueFromGo(item)
+		}
+		defer func() {
+			for _, item := range items {
+				C.as_value_free(item)
+			}
+		}()
+		var itemsPtr **C.AsValue
+		if len(items) > 0 {
+			itemsPtr = &items[0]
+		}
+		return C.as_value_new_list(itemsPtr, C.int(len(items)))
+	case KindError:
+		return cErrorValue(v.Str)
+	default:
+		return C.as_value_new_bool(C.int(0))
+	}
+}
@@ -0,0 +1,62 @@
+package aslang
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRegisterBuiltinLookup(t *testing.T) {
+	called := false
+	handle := registerBuiltin(func(args []Value) (Value, error) {
+		called = true
+		return Value{Kind: KindInt, Int: 42}, nil
+	})
+
+	fn, ok := lookupBuiltin(handle)
+	if !ok {
+		t.Fatalf("lookupBuiltin(%d): not found", handle)
+	}
+
+	result, err := fn(nil)
+	if err != nil {
+		t.Fatalf("fn(nil): unexpected error: %v", err)
+	}
+	if !called {
+		t.Fatalf("registered builtin was not invoked")
+	}
+	if result.Kind != KindInt || result.Int != 42 {
+		t.Fatalf("fn(nil) = %+v, want Kind=KindInt Int=42", result)
+	}
+}
+
+func TestRegisterBuiltinDistinctHandles(t *testing.T) {
+	h1 := registerBuiltin(func(args []Value) (Value, error) { return Value{}, nil })
+	h2 := registerBuiltin(func(args []Value) (Value, error) { return Value{}, nil })
+
+	if h1 == h2 {
+		t.Fatalf("registerBuiltin returned the same handle twice: %d", h1)
+	}
+}
+
+func TestLookupBuiltinUnknownHandle(t *testing.T) {
+	if _, ok := lookupBuiltin(^uintptr(0)); ok {
+		t.Fatalf("lookupBuiltin found a builtin for a handle that was never registered")
+	}
+}
+
+func TestRegisterBuiltinPropagatesError(t *testing.T) {
+	sentinel := errors.New("boom")
+	handle := registerBuiltin(func(args []Value) (Value, error) {
+		return Value{}, sentinel
+	})
+
+	fn, ok := lookupBuiltin(handle)
+	if !ok {
+		t.Fatalf("lookupBuiltin(%d): not found", handle)
+	}
+
+	_, err := fn(nil)
+	if !errors.Is(err, sentinel) {
+		t.Fatalf("fn(nil) error = %v, want %v", err, sentinel)
+	}
+}
@@ -0,0 +1,171 @@
+package aslang
+
+/*
+#include <stdlib.h>
+#include <stdint.h>
+
+typedef struct {
+	char* stdout_data;
+	char* stderr_data;
+	int   exit_code;
+	char* diagnostics_json;
+	char* value_json;
+} AsResult;
+
+typedef struct {
+	uint64_t wall_clock_ms;
+	uint64_t max_instructions;
+	uint64_t max_heap_bytes;
+	uint32_t max_recursion_depth;
+} AsLimits;
+
+extern AsResult* as_execute_cancelable(const char* code, uint32_t* cancel_flag, AsLimits limits);
+extern AsResult* as_session_eval_cancelable(void* session, const char* code, uint32_t* cancel_flag, AsLimits limits);
+extern void as_free_result(AsResult* result);
+*/
+import "C"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+// Limits bounds the resources a single execution may consume. The zero value
+// of each field means that dimension is unbounded, so the zero Limits{}
+// imposes no limit at all.
+type Limits struct {
+	WallClock         time.Duration
+	MaxInstructions   uint64
+	MaxHeapBytes      uint64
+	MaxRecursionDepth uint32
+}
+
+// Sentinel errors surfaced when an execution is stopped by ExecuteContext
+// rather than failing on its own.
+var (
+	ErrTimeout           = errors.New("aslang: execution timed out")
+	ErrInstructionBudget = errors.New("aslang: instruction budget exhausted")
+	ErrStackOverflow     = errors.New("aslang: recursion depth limit exceeded")
+)
+
+// exitCode values used by as_execute_cancelable/as_session_eval_cancelable to
+// report why an execution was stopped early, distinct from ordinary
+// parse/runtime failures.
+const (
+	exitTimeout           = -1
+	exitInstructionBudget = -2
+	exitStackOverflow     = -3
+)
+
+func (l Limits) toC() C.AsLimits {
+	return C.AsLimits{
+		wall_clock_ms:       C.uint64_t(l.WallClock.Milliseconds()),
+		max_instructions:    C.uint64_t(l.MaxInstructions),
+		max_heap_bytes:      C.uint64_t(l.MaxHeapBytes),
+		max_recursion_depth: C.uint32_t(l.MaxRecursionDepth),
+	}
+}
+
+// ExecuteContext runs code like Execute, but aborts early if ctx is canceled
+// or limits are exceeded. The interpreter polls a shared cancel flag between
+// bytecode steps and loop backedges, so cancellation is observed promptly
+// even for long-running or infinite-looping scripts.
+func ExecuteContext(ctx context.Context, code string, limits Limits) (Result, error) {
+	var cancelFlag uint32
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			atomic.StoreUint32(&cancelFlag, 1)
+		case <-done:
+		}
+	}()
+
+	cCode := C.CString(code)
+	defer C.free(unsafe.Pointer(cCode))
+
+	cResult := C.as_execute_cancelable(cCode, (*C.uint32_t)(unsafe.Pointer(&cancelFlag)), limits.toC())
+	defer C.as_free_result(cResult)
+
+	return decodeCancelableResult(cResult)
+}
+
+// EvalContext runs code against the session's existing environment, same as
+// Eval, but aborts early if ctx is canceled or limits are exceeded.
+func (s *Session) EvalContext(ctx context.Context, code string, limits Limits) (Result, error) {
+	if s.ptr == nil {
+		return Result{}, fmt.Errorf("aslang: session is closed")
+	}
+
+	var cancelFlag uint32
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			atomic.StoreUint32(&cancelFlag, 1)
+		case <-done:
+		}
+	}()
+
+	cCode := C.CString(code)
+	defer C.free(unsafe.Pointer(cCode))
+
+	cResult := C.as_session_eval_cancelable(s.ptr, cCode, (*C.uint32_t)(unsafe.Pointer(&cancelFlag)), limits.toC())
+	defer C.as_free_result(cResult)
+
+	result, err := decodeCancelableResult(cResult)
+	runtime.KeepAlive(s)
+	return result, err
+}
+
+func decodeCancelableResult(cResult *C.AsResult) (Result, error) {
+	return decodeResultFields(
+		C.GoString(cResult.stdout_data),
+		C.GoString(cResult.stderr_data),
+		C.GoString(cResult.diagnostics_json),
+		C.GoString(cResult.value_json),
+		int(cResult.exit_code),
+	)
+}
+
+// decodeResultFields turns the flattened fields of an AsResult into a Result
+// and, where applicable, a typed error. It takes plain Go values rather than
+// *C.AsResult so it can be exercised directly without cgo.
+func decodeResultFields(stdout, stderr, diagnosticsJSON, valueJSON string, exitCode int) (Result, error) {
+	result := Result{
+		Stdout:   stdout,
+		Stderr:   stderr,
+		ExitCode: exitCode,
+	}
+	if valueJSON != "" {
+		result.Value = json.RawMessage(valueJSON)
+	}
+
+	if diagnosticsJSON != "" {
+		if err := json.Unmarshal([]byte(diagnosticsJSON), &result.Diagnostics); err != nil {
+			return result, fmt.Errorf("aslang: decoding diagnostics: %w", err)
+		}
+	}
+
+	switch exitCode {
+	case 0:
+		return result, nil
+	case exitTimeout:
+		return result, ErrTimeout
+	case exitInstructionBudget:
+		return result, ErrInstructionBudget
+	case exitStackOverflow:
+		return result, ErrStackOverflow
+	default:
+		return result, &ExecError{Result: result}
+	}
+}
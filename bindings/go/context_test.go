@@ -0,0 +1,82 @@
+package aslang
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecodeResultFieldsSuccess(t *testing.T) {
+	result, err := decodeResultFields("hi", "", "", "", 0)
+	if err != nil {
+		t.Fatalf("decodeResultFields: unexpected error: %v", err)
+	}
+	if result.Stdout != "hi" {
+		t.Fatalf("result.Stdout = %q, want %q", result.Stdout, "hi")
+	}
+}
+
+func TestDecodeResultFieldsExitCodes(t *testing.T) {
+	cases := []struct {
+		name     string
+		exitCode int
+		wantErr  error
+	}{
+		{"timeout", exitTimeout, ErrTimeout},
+		{"instruction budget", exitInstructionBudget, ErrInstructionBudget},
+		{"stack overflow", exitStackOverflow, ErrStackOverflow},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := decodeResultFields("", "", "", "", c.exitCode)
+			if !errors.Is(err, c.wantErr) {
+				t.Fatalf("decodeResultFields exit code %d: err = %v, want %v", c.exitCode, err, c.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecodeResultFieldsUnknownExitCodeIsExecError(t *testing.T) {
+	_, err := decodeResultFields("", "boom", "", "", 7)
+	var execErr *ExecError
+	if !errors.As(err, &execErr) {
+		t.Fatalf("decodeResultFields exit code 7: err = %v, want *ExecError", err)
+	}
+}
+
+func TestDecodeResultFieldsInvalidDiagnosticsJSON(t *testing.T) {
+	if _, err := decodeResultFields("", "", "not json", "", 0); err == nil {
+		t.Fatalf("decodeResultFields: expected an error decoding invalid diagnostics JSON")
+	}
+}
+
+func TestDecodeResultFieldsParsesDiagnostics(t *testing.T) {
+	diagnostics := `[{"line":1,"col":2,"severity":"error","message":"boom"}]`
+	result, err := decodeResultFields("", "", diagnostics, "", 1)
+	if err == nil {
+		t.Fatalf("decodeResultFields: expected a non-nil error for exit code 1")
+	}
+	if len(result.Diagnostics) != 1 || result.Diagnostics[0].Message != "boom" {
+		t.Fatalf("result.Diagnostics = %+v, want one diagnostic with message %q", result.Diagnostics, "boom")
+	}
+}
+
+func TestDecodeResultFieldsParsesValue(t *testing.T) {
+	result, err := decodeResultFields("", "", "", `{"ok":true}`, 0)
+	if err != nil {
+		t.Fatalf("decodeResultFields: unexpected error: %v", err)
+	}
+	if string(result.Value) != `{"ok":true}` {
+		t.Fatalf("result.Value = %s, want %s", result.Value, `{"ok":true}`)
+	}
+}
+
+func TestDecodeResultFieldsEmptyValueIsNil(t *testing.T) {
+	result, err := decodeResultFields("", "", "", "", 0)
+	if err != nil {
+		t.Fatalf("decodeResultFields: unexpected error: %v", err)
+	}
+	if result.Value != nil {
+		t.Fatalf("result.Value = %s, want nil", result.Value)
+	}
+}
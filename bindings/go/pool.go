@@ -0,0 +1,222 @@
+package aslang
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// PoolOptions configures a Pool.
+type PoolOptions struct {
+	// Limits applies to every job submitted through the pool.
+	Limits Limits
+	// QueueDepth bounds how many jobs may wait for a free session at once.
+	// Once that many callers are already waiting, further Do/Map calls block
+	// inside Do itself until a waiting slot frees up, instead of growing the
+	// wait set without bound. Zero means unbounded.
+	QueueDepth int
+}
+
+// pooledSession is the subset of *Session the pool depends on, factored out
+// so tests can exercise queueing and cancellation semantics with a fake
+// instead of a real cgo-backed session.
+type pooledSession interface {
+	EvalContext(ctx context.Context, code string, limits Limits) (Result, error)
+	Close()
+}
+
+// Pool multiplexes Execute-style requests across a fixed set of reusable
+// interpreter sessions, so compilation caches and interned strings survive
+// across jobs instead of paying session setup cost per call.
+type Pool struct {
+	sessions  chan pooledSession
+	admission chan struct{} // nil when opts.QueueDepth <= 0 (unbounded)
+	opts      PoolOptions
+	inFlight  sync.WaitGroup
+
+	executed atomic.Uint64
+	failed   atomic.Uint64
+	queued   atomic.Int64
+	latency  latencyHistogram
+}
+
+// NewPool creates a Pool of size long-lived sessions.
+func NewPool(size int, opts PoolOptions) *Pool {
+	if size <= 0 {
+		size = 1
+	}
+
+	p := newPool(opts)
+	p.sessions = make(chan pooledSession, size)
+	for i := 0; i < size; i++ {
+		p.sessions <- NewSession()
+	}
+	return p
+}
+
+// newPoolWithSessions builds a Pool around caller-supplied sessions, letting
+// tests inject fakes instead of real cgo-backed ones.
+func newPoolWithSessions(sessions []pooledSession, opts PoolOptions) *Pool {
+	p := newPool(opts)
+	p.sessions = make(chan pooledSession, len(sessions))
+	for _, s := range sessions {
+		p.sessions <- s
+	}
+	return p
+}
+
+func newPool(opts PoolOptions) *Pool {
+	p := &Pool{opts: opts}
+	if opts.QueueDepth > 0 {
+		p.admission = make(chan struct{}, opts.QueueDepth)
+	}
+	return p
+}
+
+// Do runs code on the next available session, honoring ctx for cancellation.
+// If opts.QueueDepth is exceeded, Do blocks here before it even starts
+// waiting for a session.
+func (p *Pool) Do(ctx context.Context, code string) (Result, error) {
+	p.inFlight.Add(1)
+	defer p.inFlight.Done()
+
+	if p.admission != nil {
+		select {
+		case p.admission <- struct{}{}:
+		case <-ctx.Done():
+			return Result{}, ctx.Err()
+		}
+	}
+
+	p.queued.Add(1)
+	var session pooledSession
+	select {
+	case session = <-p.sessions:
+		p.queued.Add(-1)
+	case <-ctx.Done():
+		p.queued.Add(-1)
+		if p.admission != nil {
+			<-p.admission
+		}
+		return Result{}, ctx.Err()
+	}
+	if p.admission != nil {
+		<-p.admission
+	}
+	defer func() { p.sessions <- session }()
+
+	start := time.Now()
+	result, err := session.EvalContext(ctx, code, p.opts.Limits)
+	p.latency.observe(time.Since(start))
+
+	p.executed.Add(1)
+	if err != nil {
+		p.failed.Add(1)
+	}
+	return result, err
+}
+
+// Map runs codes across the pool concurrently, returning results in the same
+// order as codes. If ctx is canceled, Map returns as soon as the in-flight
+// jobs unwind.
+func (p *Pool) Map(ctx context.Context, codes []string) ([]Result, error) {
+	results := make([]Result, len(codes))
+	errs := make([]error, len(codes))
+
+	var wg sync.WaitGroup
+	for i, code := range codes {
+		wg.Add(1)
+		go func(i int, code string) {
+			defer wg.Done()
+			results[i], errs[i] = p.Do(ctx, code)
+		}(i, code)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return results, fmt.Errorf("aslang: pool map: %w", err)
+		}
+	}
+	return results, nil
+}
+
+// Close waits for every outstanding Do/Map call to finish, then releases
+// every session owned by the pool. Callers must stop submitting new jobs
+// before calling Close, or it may block indefinitely.
+func (p *Pool) Close() {
+	p.inFlight.Wait()
+	close(p.sessions)
+	for session := range p.sessions {
+		session.Close()
+	}
+}
+
+// Stats is a snapshot of the pool's Prometheus-style counters.
+type Stats struct {
+	Executed   uint64
+	Failed     uint64
+	QueueDepth int64
+	LatencyP50 time.Duration
+	LatencyP99 time.Duration
+}
+
+// Stats returns a snapshot of the pool's counters.
+func (p *Pool) Stats() Stats {
+	p50, p99 := p.latency.quantiles()
+	return Stats{
+		Executed:   p.executed.Load(),
+		Failed:     p.failed.Load(),
+		QueueDepth: p.queued.Load(),
+		LatencyP50: p50,
+		LatencyP99: p99,
+	}
+}
+
+// latencyHistogram is a minimal fixed-size ring buffer used to compute rough
+// latency quantiles without pulling in a metrics dependency.
+type latencyHistogram struct {
+	mu     sync.Mutex
+	values []time.Duration
+	next   int
+}
+
+const latencyHistogramSize = 256
+
+func (h *latencyHistogram) observe(d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.values == nil {
+		h.values = make([]time.Duration, 0, latencyHistogramSize)
+	}
+	if len(h.values) < latencyHistogramSize {
+		h.values = append(h.values, d)
+	} else {
+		h.values[h.next] = d
+		h.next = (h.next + 1) % latencyHistogramSize
+	}
+}
+
+func (h *latencyHistogram) quantiles() (p50, p99 time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.values) == 0 {
+		return 0, 0
+	}
+
+	sorted := append([]time.Duration(nil), h.values...)
+	for i := 1; i < len(sorted); i++ {
+		for j := i; j > 0 && sorted[j] < sorted[j-1]; j-- {
+			sorted[j], sorted[j-1] = sorted[j-1], sorted[j]
+		}
+	}
+
+	p50Idx := len(sorted) * 50 / 100
+	p99Idx := len(sorted) * 99 / 100
+	if p99Idx >= len(sorted) {
+		p99Idx = len(sorted) - 1
+	}
+	return sorted[p50Idx], sorted[p99Idx]
+}
@@ -0,0 +1,196 @@
+package aslang
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeSession is a pooledSession that blocks until release is closed, so
+// tests can control exactly when a job finishes.
+type fakeSession struct {
+	release chan struct{}
+	closed  bool
+}
+
+func newFakeSession() *fakeSession {
+	return &fakeSession{release: make(chan struct{})}
+}
+
+func (f *fakeSession) EvalContext(ctx context.Context, code string, limits Limits) (Result, error) {
+	select {
+	case <-f.release:
+		return Result{Stdout: code}, nil
+	case <-ctx.Done():
+		return Result{}, ctx.Err()
+	}
+}
+
+func (f *fakeSession) Close() { f.closed = true }
+
+func TestPoolDoReturnsSessionToChannel(t *testing.T) {
+	s := newFakeSession()
+	close(s.release)
+	p := newPoolWithSessions([]pooledSession{s}, PoolOptions{})
+
+	if _, err := p.Do(context.Background(), "1 + 1"); err != nil {
+		t.Fatalf("Do: unexpected error: %v", err)
+	}
+	if _, err := p.Do(context.Background(), "2 + 2"); err != nil {
+		t.Fatalf("Do: unexpected error on second call (session not returned?): %v", err)
+	}
+}
+
+func TestPoolDoCancelWhileWaitingForSession(t *testing.T) {
+	s := newFakeSession() // never released, so the one worker stays busy
+	p := newPoolWithSessions([]pooledSession{s}, PoolOptions{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	go func() {
+		close(started)
+		p.Do(context.Background(), "busy forever")
+	}()
+	<-started
+	time.Sleep(10 * time.Millisecond) // let the first Do acquire the only session
+
+	cancel()
+	_, err := p.Do(ctx, "should not run")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Do with canceled ctx: err = %v, want context.Canceled", err)
+	}
+}
+
+func TestPoolStatsQueueDepthExcludesExecutionTime(t *testing.T) {
+	s := newFakeSession()
+	p := newPoolWithSessions([]pooledSession{s}, PoolOptions{})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		p.Do(context.Background(), "slow")
+	}()
+
+	// Give the worker a moment to acquire the (only) session and start
+	// executing; QueueDepth should already be back to zero since nothing
+	// else is waiting for a free session.
+	time.Sleep(10 * time.Millisecond)
+	if got := p.Stats().QueueDepth; got != 0 {
+		t.Fatalf("QueueDepth = %d while a job is executing (not queued), want 0", got)
+	}
+
+	close(s.release)
+	<-done
+}
+
+func TestPoolMapPreservesOrder(t *testing.T) {
+	s1, s2 := newFakeSession(), newFakeSession()
+	close(s1.release)
+	close(s2.release)
+	p := newPoolWithSessions([]pooledSession{s1, s2}, PoolOptions{})
+
+	results, err := p.Map(context.Background(), []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("Map: unexpected error: %v", err)
+	}
+	for i, code := range []string{"a", "b", "c"} {
+		if results[i].Stdout != code {
+			t.Fatalf("results[%d].Stdout = %q, want %q", i, results[i].Stdout, code)
+		}
+	}
+}
+
+func TestPoolCloseWaitsForInFlight(t *testing.T) {
+	s := newFakeSession()
+	p := newPoolWithSessions([]pooledSession{s}, PoolOptions{})
+
+	doReturned := make(chan struct{})
+	go func() {
+		p.Do(context.Background(), "slow")
+		close(doReturned)
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	closeReturned := make(chan struct{})
+	go func() {
+		p.Close()
+		close(closeReturned)
+	}()
+
+	select {
+	case <-closeReturned:
+		t.Fatalf("Close returned before the in-flight Do call finished")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(s.release)
+	<-doReturned
+	<-closeReturned
+
+	if !s.closed {
+		t.Fatalf("Close did not close the underlying session")
+	}
+}
+
+func TestPoolQueueDepthBoundsWaiters(t *testing.T) {
+	s := newFakeSession() // never released, so the one worker stays busy forever
+	p := newPoolWithSessions([]pooledSession{s}, PoolOptions{QueueDepth: 1})
+
+	busyStarted := make(chan struct{})
+	go func() {
+		close(busyStarted)
+		p.Do(context.Background(), "busy forever")
+	}()
+	<-busyStarted
+	time.Sleep(10 * time.Millisecond) // let it take the only session
+
+	queuedStarted := make(chan struct{})
+	go func() {
+		close(queuedStarted)
+		p.Do(context.Background(), "fills the one admission slot")
+	}()
+	<-queuedStarted
+	time.Sleep(10 * time.Millisecond) // let it fill the admission slot
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := p.Do(ctx, "should never be admitted"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Do with a full admission queue: err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestLatencyHistogramQuantiles(t *testing.T) {
+	h := &latencyHistogram{}
+	for i := 1; i <= 100; i++ {
+		h.observe(time.Duration(i) * time.Millisecond)
+	}
+
+	p50, p99 := h.quantiles()
+	if p50 != 51*time.Millisecond {
+		t.Fatalf("p50 = %v, want %v", p50, 51*time.Millisecond)
+	}
+	if p99 != 100*time.Millisecond {
+		t.Fatalf("p99 = %v, want %v", p99, 100*time.Millisecond)
+	}
+}
+
+func TestLatencyHistogramEmpty(t *testing.T) {
+	h := &latencyHistogram{}
+	p50, p99 := h.quantiles()
+	if p50 != 0 || p99 != 0 {
+		t.Fatalf("quantiles on empty histogram = (%v, %v), want (0, 0)", p50, p99)
+	}
+}
+
+func TestLatencyHistogramWrapsAroundRingBuffer(t *testing.T) {
+	h := &latencyHistogram{}
+	for i := 0; i < latencyHistogramSize+10; i++ {
+		h.observe(time.Duration(i) * time.Millisecond)
+	}
+
+	p50, _ := h.quantiles()
+	if p50 == 0 {
+		t.Fatalf("p50 = 0 after wrapping, want a representative non-zero value")
+	}
+}
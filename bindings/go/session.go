@@ -0,0 +1,86 @@
+package aslang
+
+/*
+#include <stdlib.h>
+
+typedef struct {
+	char* stdout_data;
+	char* stderr_data;
+	int   exit_code;
+	char* diagnostics_json;
+	char* value_json;
+} AsResult;
+
+extern void* as_session_new();
+extern AsResult* as_session_eval(void* session, const char* code);
+extern void as_session_reset(void* session);
+extern void as_session_free(void* session);
+extern void as_free_result(AsResult* result);
+*/
+import "C"
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+)
+
+// Session is a persistent AS Lang interpreter. Unlike Execute, a Session
+// retains its global environment (bindings, user-defined functions, imports)
+// across calls to Eval, which makes it suitable for REPLs, notebook-style
+// evaluation, and long-lived embedded scripting.
+type Session struct {
+	ptr unsafe.Pointer
+}
+
+// NewSession creates a fresh interpreter session with an empty environment.
+func NewSession() *Session {
+	s := &Session{ptr: unsafe.Pointer(C.as_session_new())}
+	runtime.SetFinalizer(s, (*Session).Close)
+	return s
+}
+
+// Eval runs code against the session's existing environment and returns a
+// structured Result, same as Execute.
+func (s *Session) Eval(code string) (Result, error) {
+	if s.ptr == nil {
+		return Result{}, fmt.Errorf("aslang: session is closed")
+	}
+
+	cCode := C.CString(code)
+	defer C.free(unsafe.Pointer(cCode))
+
+	cResult := C.as_session_eval(s.ptr, cCode)
+	defer C.as_free_result(cResult)
+
+	result, err := decodeResultFields(
+		C.GoString(cResult.stdout_data),
+		C.GoString(cResult.stderr_data),
+		C.GoString(cResult.diagnostics_json),
+		C.GoString(cResult.value_json),
+		int(cResult.exit_code),
+	)
+	runtime.KeepAlive(s)
+	return result, err
+}
+
+// Reset clears the session's environment, discarding all bindings and
+// user-defined functions while keeping the session handle alive.
+func (s *Session) Reset() {
+	if s.ptr == nil {
+		return
+	}
+	C.as_session_reset(s.ptr)
+	runtime.KeepAlive(s)
+}
+
+// Close releases the underlying Rust interpreter. It is safe to call Close
+// more than once, and Close is also invoked automatically by a finalizer if
+// the caller forgets.
+func (s *Session) Close() {
+	if s.ptr == nil {
+		return
+	}
+	C.as_session_free(s.ptr)
+	s.ptr = nil
+	runtime.SetFinalizer(s, nil)
+}
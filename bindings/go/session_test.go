@@ -0,0 +1,47 @@
+package aslang
+
+import (
+	"context"
+	"testing"
+)
+
+// A zero-value Session has ptr == nil, the same state a closed Session ends
+// up in, so these exercise the closed-session guards in Eval, EvalContext,
+// Register, Reset, and Close without touching cgo.
+
+func TestSessionEvalOnClosedSession(t *testing.T) {
+	s := &Session{}
+	if _, err := s.Eval("1 + 1"); err == nil {
+		t.Fatalf("Eval on a closed session: expected an error")
+	}
+}
+
+func TestSessionEvalContextOnClosedSession(t *testing.T) {
+	s := &Session{}
+	if _, err := s.EvalContext(context.Background(), "1 + 1", Limits{}); err == nil {
+		t.Fatalf("EvalContext on a closed session: expected an error")
+	}
+}
+
+func TestSessionRegisterOnClosedSession(t *testing.T) {
+	s := &Session{}
+	err := s.Register("noop", 0, func(args []Value) (Value, error) {
+		return Value{}, nil
+	})
+	if err == nil {
+		t.Fatalf("Register on a closed session: expected an error")
+	}
+}
+
+func TestSessionResetOnClosedSession(t *testing.T) {
+	s := &Session{}
+	s.Reset() // must not panic or touch cgo
+}
+
+func TestSessionCloseOnAlreadyClosedSession(t *testing.T) {
+	s := &Session{}
+	s.Close() // must be a no-op, not a panic
+	if s.ptr != nil {
+		t.Fatalf("Close on an already-closed session: ptr = %v, want nil", s.ptr)
+	}
+}
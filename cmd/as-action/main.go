@@ -0,0 +1,41 @@
+// Command as-action serves AS Lang scripts over HTTP using the OpenWhisk
+// action protocol, so a precompiled script can run as a drop-in serverless
+// action.
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	aslang "github.com/ashuwhy/lang.as/bindings/go"
+	"github.com/ashuwhy/lang.as/pkg/action"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	timeout := flag.Duration("timeout", 60*time.Second, "wall-clock limit for each /run call; 0 means unbounded")
+	flag.Parse()
+
+	runtime := action.NewRuntime(action.RuntimeOptions{
+		Limits: aslang.Limits{WallClock: *timeout},
+	})
+
+	if stat, err := os.Stdin.Stat(); err == nil && (stat.Mode()&os.ModeCharDevice) == 0 {
+		code, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			log.Fatalf("as-action: reading code from stdin: %v", err)
+		}
+		if err := runtime.Init(string(code)); err != nil {
+			log.Fatalf("as-action: %v", err)
+		}
+	}
+
+	log.Printf("as-action: listening on %s", *addr)
+	if err := http.ListenAndServe(*addr, runtime.Handler()); err != nil {
+		log.Fatalf("as-action: %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+// Command demo exercises the aslang bindings end to end.
+package main
+
+import (
+	"fmt"
+
+	aslang "github.com/ashuwhy/lang.as/bindings/go"
+)
+
+func main() {
+	code := `print("Hello from Go!"); let x = 40 + 2; print(x);`
+	fmt.Printf("Running AS Lang from Go...\n")
+
+	result, err := aslang.Execute(code)
+	if err != nil {
+		fmt.Printf("Execute failed: %v\n", err)
+		return
+	}
+	fmt.Printf("Output:\n%s\n", result.Stdout)
+}
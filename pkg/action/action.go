@@ -0,0 +1,167 @@
+// Package action implements an OpenWhisk-style action runtime for AS Lang
+// scripts: POST /init compiles a script into a long-lived session, and
+// POST /run binds JSON arguments to a main(args) call and returns its result.
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	aslang "github.com/ashuwhy/lang.as/bindings/go"
+)
+
+// actionSession is the subset of *aslang.Session the runtime depends on,
+// factored out so tests can exercise Run's stdout/result handling with a
+// fake instead of a real cgo-backed session.
+type actionSession interface {
+	Eval(code string) (aslang.Result, error)
+	EvalContext(ctx context.Context, code string, limits aslang.Limits) (aslang.Result, error)
+	Close()
+}
+
+// RuntimeOptions configures a Runtime.
+type RuntimeOptions struct {
+	// Limits applies to every /run call. The zero value of aslang.Limits
+	// means no limit is enforced, so operators that want /run to ever time
+	// out or get killed for runaway resource use must set it explicitly.
+	Limits aslang.Limits
+}
+
+// Runtime serves the OpenWhisk action protocol over HTTP, backed by a single
+// AS Lang session that is compiled once on /init and reused by every /run.
+type Runtime struct {
+	mu      sync.Mutex
+	session actionSession
+	opts    RuntimeOptions
+}
+
+// NewRuntime creates a Runtime with no code loaded yet; callers must POST to
+// /init (or call Init) before /run will succeed.
+func NewRuntime(opts RuntimeOptions) *Runtime {
+	return &Runtime{opts: opts}
+}
+
+type initRequest struct {
+	Value struct {
+		Code string `json:"code"`
+	} `json:"value"`
+}
+
+type runRequest struct {
+	Value json.RawMessage `json:"value"`
+}
+
+type runResponse struct {
+	Stdout string          `json:"stdout,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Init compiles code into a fresh session, replacing any previously loaded
+// code.
+func (r *Runtime) Init(code string) error {
+	session := aslang.NewSession()
+	if _, err := session.Eval(code); err != nil {
+		session.Close()
+		return fmt.Errorf("action: compiling init code: %w", err)
+	}
+
+	r.mu.Lock()
+	old := r.session
+	r.session = session
+	r.mu.Unlock()
+
+	if old != nil {
+		old.Close()
+	}
+	return nil
+}
+
+// Run binds args to a call to main(args) in the loaded session and returns
+// the script's result plus any captured stdout.
+func (r *Runtime) Run(ctx context.Context, args json.RawMessage) (stdout string, result json.RawMessage, err error) {
+	r.mu.Lock()
+	session := r.session
+	r.mu.Unlock()
+
+	if session == nil {
+		return "", nil, fmt.Errorf("action: no code loaded, call /init first")
+	}
+
+	call := fmt.Sprintf("main(%s)", string(args))
+	evalResult, err := session.EvalContext(ctx, call, r.opts.Limits)
+	if err != nil {
+		return evalResult.Stdout, nil, err
+	}
+
+	return evalResult.Stdout, evalResult.Value, nil
+}
+
+// Handler returns an http.Handler implementing /init, /run, and /health.
+func (r *Runtime) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/init", r.handleInit)
+	mux.HandleFunc("/run", r.handleRun)
+	mux.HandleFunc("/health", r.handleHealth)
+	return mux
+}
+
+func (r *Runtime) handleInit(w http.ResponseWriter, req *http.Request) {
+	var in initRequest
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := r.Init(in.Value.Code); err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (r *Runtime) handleRun(w http.ResponseWriter, req *http.Request) {
+	var in runRequest
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	stdout, result, err := r.Run(req.Context(), in.Value)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, runResponse{Stdout: stdout, Result: result})
+}
+
+func (r *Runtime) handleHealth(w http.ResponseWriter, req *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+// writeJSON marshals v before writing anything to w, so a marshaling failure
+// (e.g. an invalid Result field) surfaces as a clean error response instead
+// of a 200 with a truncated or empty body.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, fmt.Errorf("action: encoding response: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,114 @@
+package action
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	aslang "github.com/ashuwhy/lang.as/bindings/go"
+)
+
+// fakeSession is an actionSession that returns a canned Result, so Run can
+// be tested without a real cgo-backed interpreter.
+type fakeSession struct {
+	result aslang.Result
+	err    error
+}
+
+func (f *fakeSession) Eval(code string) (aslang.Result, error) { return f.result, f.err }
+func (f *fakeSession) EvalContext(ctx context.Context, code string, limits aslang.Limits) (aslang.Result, error) {
+	return f.result, f.err
+}
+func (f *fakeSession) Close() {}
+
+func TestRuntimeRunReturnsValueNotStdout(t *testing.T) {
+	r := &Runtime{session: &fakeSession{
+		result: aslang.Result{
+			Stdout: "log line, not JSON",
+			Value:  json.RawMessage(`{"sum":3}`),
+		},
+	}}
+
+	stdout, result, err := r.Run(context.Background(), json.RawMessage(`{"a":1,"b":2}`))
+	if err != nil {
+		t.Fatalf("Run: unexpected error: %v", err)
+	}
+	if stdout != "log line, not JSON" {
+		t.Fatalf("stdout = %q, want %q", stdout, "log line, not JSON")
+	}
+	if string(result) != `{"sum":3}` {
+		t.Fatalf("result = %s, want %s", result, `{"sum":3}`)
+	}
+}
+
+func TestRuntimeRunWithNoCodeLoaded(t *testing.T) {
+	r := NewRuntime(RuntimeOptions{})
+	if _, _, err := r.Run(context.Background(), json.RawMessage(`{}`)); err == nil {
+		t.Fatalf("Run: expected an error when no code has been loaded")
+	}
+}
+
+func TestRuntimeRunPropagatesEvalError(t *testing.T) {
+	wantErr := errFixture("boom")
+	r := &Runtime{session: &fakeSession{err: wantErr}}
+
+	_, _, err := r.Run(context.Background(), json.RawMessage(`{}`))
+	if err != wantErr {
+		t.Fatalf("Run: err = %v, want %v", err, wantErr)
+	}
+}
+
+type errFixture string
+
+func (e errFixture) Error() string { return string(e) }
+
+func TestHandleRunOmitsResultWhenValueIsNil(t *testing.T) {
+	// A stdout-only script with no evaluated value produces a nil Value;
+	// handleRun must still respond 200 with an omitted result field rather
+	// than trying to treat stdout as JSON.
+	r := &Runtime{session: &fakeSession{result: aslang.Result{Stdout: "not json at all"}}}
+
+	req := httptest.NewRequest("POST", "/run", strings.NewReader(`{"value":{}}`))
+	w := httptest.NewRecorder()
+	r.handleRun(w, req)
+
+	if w.Code != 200 {
+		t.Fatalf("status = %d, want 200; body = %s", w.Code, w.Body.String())
+	}
+
+	var resp runResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("response body is not valid JSON: %v (body=%s)", err, w.Body.String())
+	}
+	if resp.Stdout != "not json at all" {
+		t.Fatalf("resp.Stdout = %q, want %q", resp.Stdout, "not json at all")
+	}
+	if resp.Result != nil {
+		t.Fatalf("resp.Result = %s, want nil", resp.Result)
+	}
+}
+
+func TestHandleRunWritesBadGatewayOnUnmarshalableResult(t *testing.T) {
+	// A Value that isn't valid JSON can't be embedded in the response, so
+	// writeJSON's marshal failure must surface as a 502 error response
+	// instead of a 200 with a truncated or malformed body.
+	r := &Runtime{session: &fakeSession{result: aslang.Result{Value: json.RawMessage("not valid json")}}}
+
+	req := httptest.NewRequest("POST", "/run", strings.NewReader(`{"value":{}}`))
+	w := httptest.NewRecorder()
+	r.handleRun(w, req)
+
+	if w.Code != 502 {
+		t.Fatalf("status = %d, want 502; body = %s", w.Code, w.Body.String())
+	}
+
+	var resp errorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("response body is not valid JSON: %v (body=%s)", err, w.Body.String())
+	}
+	if resp.Error == "" {
+		t.Fatalf("resp.Error is empty, want a message describing the marshal failure")
+	}
+}